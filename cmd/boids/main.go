@@ -1,17 +1,18 @@
 package main
 
 import (
+    "encoding/json"
     "flag"
     "fmt"
     "log"
     "net/http"
+    "net/url"
     "os"
     "path/filepath"
     "strings"
     "time"
 
     "boidfactory/local/internal/generator"
-    "os/exec"
 )
 
 func main() {
@@ -55,15 +56,10 @@ func main() {
     fmt.Println("  ?hue=210&header=Hello&subheader=Go+generator")
 
     if *buildIndex {
-        // If the helper exists, call it to build an index.html viewer.
-        if _, err := os.Stat("build-link-viewer.sh"); err == nil {
-            if err := runIndexBuilder(folder); err != nil {
-                log.Printf("Failed to build link viewer: %v", err)
-            } else {
-                fmt.Printf("\nBuilt index.html for %s\n", folder)
-            }
+        if err := generator.BuildIndex(folder, generator.IndexOptions{}); err != nil {
+            log.Printf("Failed to build index: %v", err)
         } else {
-            log.Printf("build-link-viewer.sh not found; skipping index build")
+            fmt.Printf("\nBuilt index.html for %s\n", folder)
         }
     }
 }
@@ -81,15 +77,32 @@ func outDirPath(custom string) string {
 
 func max(a, b int) int { if a > b { return a }; return b }
 
-func runIndexBuilder(folder string) error {
-    // Best-effort: call the local Python viewer builder if available.
-    // Avoids network; uses stdlib to spawn.
-    return execPythonIndex(folder)
+// indexBaseDir confines /index's ?dir= query parameter to the same "runs"
+// tree that CLI generation writes into by default, so a remote caller can't
+// point the server at an arbitrary path on the host.
+const indexBaseDir = "runs"
+
+// resolveIndexDir validates dir (as supplied by a request) against
+// indexBaseDir, rejecting absolute paths and any "../" escape, and returns
+// the resolved path to pass to generator.RenderIndex.
+func resolveIndexDir(dir string) (string, error) {
+    if filepath.IsAbs(dir) {
+        return "", fmt.Errorf("dir must be relative to %q", indexBaseDir)
+    }
+    full := filepath.Join(indexBaseDir, dir)
+    rel, err := filepath.Rel(indexBaseDir, full)
+    if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+        return "", fmt.Errorf("dir escapes %q", indexBaseDir)
+    }
+    return full, nil
 }
 
-func execPythonIndex(folder string) error {
-    cmd := exec.Command("python3", "build-link-viewer.sh", folder)
-    return cmd.Run()
+// seededConfigFromRequest resolves the seeded-default Config for seedStr,
+// applies any query-parameter overrides, and returns the result. The seed
+// itself is not returned; an invalid override value yields an error.
+func seededConfigFromRequest(seedStr string, q url.Values) (generator.Config, error) {
+    _, cfg := generator.SeedConfig(seedStr)
+    return generator.ApplyQueryOverrides(cfg, q)
 }
 
 func runServer(addr string) {
@@ -99,9 +112,20 @@ func runServer(addr string) {
         if path == "" || path == "favicon.ico" {
             path = "home"
         }
-        // Seed from path; produce deterministic variant
-        // Also set default header to the path, and leave subheader empty unless explicitly set by URL params.
-        html, err := generator.Generate(path, path, "")
+        // Seed from path; produce deterministic variant, then layer any
+        // query-parameter overrides (?count=300&flow=true&shape=ring&...)
+        // on top before rendering.
+        cfg, err := seededConfigFromRequest(path, r.URL.Query())
+        if err != nil {
+            http.Error(w, err.Error(), http.StatusBadRequest)
+            return
+        }
+        header := path
+        if h := r.URL.Query().Get("header"); h != "" {
+            header = h
+        }
+        subheader := r.URL.Query().Get("subheader")
+        html, err := generator.GenerateWithConfig(cfg, header, subheader)
         if err != nil {
             http.Error(w, "generation error", 500)
             return
@@ -109,7 +133,36 @@ func runServer(addr string) {
         w.Header().Set("Content-Type", "text/html; charset=utf-8")
         _, _ = w.Write([]byte(html))
     })
-    log.Printf("Serving boids at %s (GET /{seed})", addr)
+    mux.HandleFunc("/config", func(w http.ResponseWriter, r *http.Request) {
+        seedStr := r.URL.Query().Get("seed")
+        cfg, err := seededConfigFromRequest(seedStr, r.URL.Query())
+        if err != nil {
+            http.Error(w, err.Error(), http.StatusBadRequest)
+            return
+        }
+        w.Header().Set("Content-Type", "application/json; charset=utf-8")
+        _ = json.NewEncoder(w).Encode(cfg)
+    })
+    mux.HandleFunc("/index", func(w http.ResponseWriter, r *http.Request) {
+        dir := r.URL.Query().Get("dir")
+        if dir == "" {
+            http.Error(w, "missing ?dir= query parameter", http.StatusBadRequest)
+            return
+        }
+        resolved, err := resolveIndexDir(dir)
+        if err != nil {
+            http.Error(w, err.Error(), http.StatusBadRequest)
+            return
+        }
+        html, err := generator.RenderIndex(resolved, generator.IndexOptions{Title: r.URL.Query().Get("title")})
+        if err != nil {
+            http.Error(w, "index generation error", 500)
+            return
+        }
+        w.Header().Set("Content-Type", "text/html; charset=utf-8")
+        _, _ = w.Write([]byte(html))
+    })
+    log.Printf("Serving boids at %s (GET /{seed}, GET /config?seed=..., GET /index?dir=...)", addr)
     if err := http.ListenAndServe(addr, mux); err != nil {
         log.Fatal(err)
     }
@@ -0,0 +1,32 @@
+package main
+
+import "testing"
+
+func TestResolveIndexDir_Valid(t *testing.T) {
+    cases := []string{"", "boids-20250101_120000_singlefile", "sub/dir"}
+    for _, dir := range cases {
+        if _, err := resolveIndexDir(dir); err != nil {
+            t.Errorf("resolveIndexDir(%q): unexpected error: %v", dir, err)
+        }
+    }
+}
+
+func TestResolveIndexDir_RejectsEscape(t *testing.T) {
+    cases := []string{
+        "../etc",
+        "..",
+        "../../../etc/passwd",
+        "sub/../../etc",
+    }
+    for _, dir := range cases {
+        if _, err := resolveIndexDir(dir); err == nil {
+            t.Errorf("resolveIndexDir(%q): expected error, got nil", dir)
+        }
+    }
+}
+
+func TestResolveIndexDir_RejectsAbsolute(t *testing.T) {
+    if _, err := resolveIndexDir("/etc"); err == nil {
+        t.Error("resolveIndexDir(\"/etc\"): expected error, got nil")
+    }
+}
@@ -7,12 +7,14 @@ import (
     "encoding/json"
     "math/rand/v2"
     "strings"
+    "text/template"
     "time"
 )
 
 //go:generate echo "templates embedded via go:embed in template.go"
 
 type Config struct {
+    Hue         int
     Count       int
     Vision      float64
     Sep         float64
@@ -63,13 +65,29 @@ type Config struct {
     Spawn       string
 }
 
+// Allowed values for Config's enum-like string fields. These are the single
+// source of truth for both defaultConfig's random selection and
+// ApplyQueryOverrides' validation of query-string overrides — a value that
+// isn't in the relevant list here is never written into a Config.
+var (
+    shapeValues         = []string{"trail", "triangle", "dot", "comet", "ring"}
+    blendValues         = []string{"lighter", "plus-lighter", "screen", "source-over"}
+    spawnValues         = []string{"random", "ring", "center", "edge", "grid"}
+    flowModeValues      = []string{"angle", "curl", "turbulence"}
+    flowColorModeValues = []string{"angle", "strength"}
+    clickModeValues     = []string{"shockwave", "gravity", "spin", "scatter"}
+    dragModeValues      = []string{"pull", "push", "spin", "flow"}
+    visionVizValues     = []string{"off", "one", "all"}
+)
+
 func defaultConfig(r *rand.Rand) Config {
     // Random but sane defaults for more variety
-    shapes := []string{"trail","triangle","dot","comet","ring"}
-    blends := []string{"lighter","plus-lighter","screen","source-over"}
-    spawns := []string{"random","ring","center","edge","grid"}
+    shapes := shapeValues
+    blends := blendValues
+    spawns := spawnValues
     flow := r.IntN(100) < 50
     return Config{
+        Hue:         int(r.IntN(360)),
         Count:       220 + r.IntN(120),
         Vision:      65 + r.Float64()*45,
         Sep:         18 + r.Float64()*10,
@@ -94,26 +112,59 @@ func defaultConfig(r *rand.Rand) Config {
         FlowSpeed:   0.4 + r.Float64()*1.0,
         FlowViz:     flow, // show viz if flow is on
         FlowVizStep: 56 + r.IntN(40),
-        FlowMode:    []string{"angle","curl","turbulence"}[r.IntN(3)],
+        FlowMode:    flowModeValues[r.IntN(len(flowModeValues))],
         FlowAmpVar:  r.Float64()*0.5,
         FlowAniso:   (r.Float64()*0.6) - 0.2, // slightly anisotropic by default
         FlowOctaves: 1 + r.IntN(4),
         FlowColor:   true,
         FlowHueScale: 40 + r.Float64()*80, // 40..120 deg
-        FlowColorMode: []string{"angle","strength"}[r.IntN(2)],
+        FlowColorMode: flowColorModeValues[r.IntN(len(flowColorModeValues))],
         FlowGlow:    flow, // default glow when viz likely on
         ShowHeader:  true,
         ShowSubheader: true,
         ShowHud:     true,
-        ClickMode:   []string{"shockwave","gravity","spin","scatter"}[r.IntN(4)],
-        DragMode:    []string{"pull","push","spin","flow"}[r.IntN(4)],
-        VisionViz:   []string{"off","one","all"}[r.IntN(3)],
+        ClickMode:   clickModeValues[r.IntN(len(clickModeValues))],
+        DragMode:    dragModeValues[r.IntN(len(dragModeValues))],
+        VisionViz:   visionVizValues[r.IntN(len(visionVizValues))],
         Shape:       shapes[r.IntN(len(shapes))],
         Blend:       blends[r.IntN(len(blends))],
         Spawn:       spawns[r.IntN(len(spawns))],
     }
 }
 
+// BoidMeta is the per-page metadata embedded in a generated file's
+// <script id="boid-meta"> tag so that tools like the index builder can
+// recover a summary of the config that produced it (the fields below, not
+// the full Config) without re-parsing the template.
+type BoidMeta struct {
+    Seed      uint64 `json:"seed"`
+    Title     string `json:"title"`
+    Count     int    `json:"count"`
+    Shape     string `json:"shape"`
+    Blend     string `json:"blend"`
+    Spawn     string `json:"spawn"`
+    Flow      bool   `json:"flow"`
+    FlowMode  string `json:"flowMode"`
+    ClickMode string `json:"clickMode"`
+    DragMode  string `json:"dragMode"`
+}
+
+// metaScriptTag renders meta as a <script id="boid-meta"> JSON blob.
+func metaScriptTag(meta BoidMeta) string {
+    b, _ := json.Marshal(meta)
+    return fmt.Sprintf(`<script id="boid-meta" type="application/json">%s</script>`, b)
+}
+
+// injectMeta embeds the boid-meta script tag just before </body>, falling
+// back to appending it at the end if the template has no </body> marker.
+func injectMeta(html string, meta BoidMeta) string {
+    tag := metaScriptTag(meta)
+    if i := strings.LastIndex(html, "</body>"); i != -1 {
+        return html[:i] + tag + html[i:]
+    }
+    return html + tag
+}
+
 func seedFromString(s string) uint64 {
     if s == "" {
         return uint64(time.Now().UnixNano())
@@ -122,64 +173,82 @@ func seedFromString(s string) uint64 {
     return binary.LittleEndian.Uint64(h[:8])
 }
 
-// GenerateFromSeed returns the single-file HTML using a deterministic seed string.
-func Generate(seedStr, header, subheader string) (string, error) {
+// configSeed derives a stable seed label from a Config's contents, for
+// callers (GenerateWithConfig) that supply a Config directly rather than
+// going through a seed string.
+func configSeed(cfg Config) uint64 {
+    b, _ := json.Marshal(cfg)
+    h := sha1.Sum(b)
+    return binary.LittleEndian.Uint64(h[:8])
+}
+
+// SeedConfig returns the seed and the seeded-default Config for seedStr
+// without rendering a page. Callers that want to override individual
+// fields (e.g. runServer applying query-string overrides) can mutate the
+// result and pass it to GenerateWithConfig.
+func SeedConfig(seedStr string) (uint64, Config) {
     seed := seedFromString(seedStr)
     r := rand.New(rand.NewPCG(uint64(seed>>1), uint64(seed<<1)|1))
-    cfg := defaultConfig(r)
-    hue := int(r.IntN(360))
-    title := fmt.Sprintf("Boids (Go) · N=%d · hue=%d", cfg.Count, hue)
+    return seed, defaultConfig(r)
+}
+
+// templateData is the root value passed to pageTemplate. Config is embedded
+// so the template can reference its fields directly (e.g. {{.Count}},
+// {{.FlowMode}}) alongside the page-level fields below.
+type templateData struct {
+    Config
+    Title         string
+    Seed          uint64
+    Header        string
+    Subheader     string
+    HeaderJSON    string
+    SubheaderJSON string
+}
+
+// Generate returns the single-file HTML using a deterministic seed string.
+func Generate(seedStr, header, subheader string) (string, error) {
+    seed, cfg := SeedConfig(seedStr)
+    return renderPage(seed, cfg, header, subheader)
+}
+
+// GenerateWithConfig renders a page from a fully-specified Config, bypassing
+// the seeded-default generation path. The seed shown in the page and
+// embedded boid-meta tag is derived from the Config itself, so the same
+// Config always reports the same seed.
+func GenerateWithConfig(cfg Config, header, subheader string) (string, error) {
+    return renderPage(configSeed(cfg), cfg, header, subheader)
+}
+
+func renderPage(seed uint64, cfg Config, header, subheader string) (string, error) {
+    title := fmt.Sprintf("Boids (Go) · N=%d · hue=%d", cfg.Count, cfg.Hue)
     if header == "" { header = "Boids — Go generator" }
     // If subheader empty, leave it empty by default. Only show when explicitly set via URL or caller.
-    data := map[string]any{
-        "Title":       title,
-        "DefaultHue":  hue,
-        "Seed":        seed,
-        "Header":      header,
-        "Subheader":   subheader,
-        "CfgCount":    cfg.Count,
-        "CfgVision":   cfg.Vision,
-        "CfgSep":      cfg.Sep,
-        "CfgMaxSpeed": cfg.MaxSpeed,
-        "CfgMinSpeed": cfg.MinSpeed,
-        "CfgMaxForce": cfg.MaxForce,
-        "CfgAlignW":   cfg.AlignW,
-        "CfgCohW":     cfg.CohesionW,
-        "CfgSepW":     cfg.SeparationW,
-        "CfgLineW":    cfg.LineWidth,
-        "CfgWrap":     cfg.Wrap,
-        "CfgTrailAlpha": cfg.TrailAlpha,
-        "CfgBgGradient": cfg.BgGradient,
-        "CfgBgHueShift1": cfg.BgHueShift1,
-        "CfgBgHueShift2": cfg.BgHueShift2,
-        "CfgBgHueShift3": cfg.BgHueShift3,
-        "CfgQt":        cfg.Qt,
-        "CfgQtCap":     cfg.QtCap,
-        "CfgFlow":      cfg.Flow,
-        "CfgFlowAmp":   cfg.FlowAmp,
-        "CfgFlowScale": cfg.FlowScale,
-        "CfgFlowSpeed": cfg.FlowSpeed,
-        "CfgFlowViz":   cfg.FlowViz,
-        "CfgFlowVizStep": cfg.FlowVizStep,
-        "CfgFlowMode":   cfg.FlowMode,
-        "CfgFlowAmpVar": cfg.FlowAmpVar,
-        "CfgFlowAniso":  cfg.FlowAniso,
-        "CfgFlowOctaves": cfg.FlowOctaves,
-        "CfgFlowColor":  cfg.FlowColor,
-        "CfgFlowHueScale": cfg.FlowHueScale,
-        "CfgFlowColorMode": cfg.FlowColorMode,
-        "CfgFlowGlow":   cfg.FlowGlow,
-        "CfgShowHeader":  cfg.ShowHeader,
-        "CfgShowSubheader": cfg.ShowSubheader,
-        "CfgShowHud":   cfg.ShowHud,
-        "CfgClickMode": cfg.ClickMode,
-        "CfgDragMode":  cfg.DragMode,
-        "CfgVisionViz": cfg.VisionViz,
-        "CfgShape":     cfg.Shape,
-        "CfgBlend":     cfg.Blend,
-        "CfgSpawn":     cfg.Spawn,
+    data := templateData{
+        Config:        cfg,
+        Title:         title,
+        Seed:          seed,
+        Header:        header,
+        Subheader:     subheader,
+        HeaderJSON:    jsonQuote(header),
+        SubheaderJSON: jsonQuote(subheader),
+    }
+    html, err := executeTemplate(pageTemplate, data)
+    if err != nil {
+        return "", err
     }
-    return executeTemplate(pageTemplate, data)
+    meta := BoidMeta{
+        Seed:      seed,
+        Title:     title,
+        Count:     cfg.Count,
+        Shape:     cfg.Shape,
+        Blend:     cfg.Blend,
+        Spawn:     cfg.Spawn,
+        Flow:      cfg.Flow,
+        FlowMode:  cfg.FlowMode,
+        ClickMode: cfg.ClickMode,
+        DragMode:  cfg.DragMode,
+    }
+    return injectMeta(html, meta), nil
 }
 
 func GenerateFromSeed(seedStr string) (string, error) {
@@ -199,62 +268,22 @@ func WriteFile(folder string, idx *int) (string, error) {
     return full, osWriteFile(full, []byte(html), 0o644)
 }
 
-func executeTemplate(tpl string, data map[string]any) (string, error) {
-    // Simple string replacement to avoid bringing in text/template escaping rules for inline JS
-    // Placeholders are of the form {{Name}}
-    out := tpl
-    repl := func(k string, v string) { out = strings.ReplaceAll(out, "{{"+k+"}}", v) }
-    // string/int conversions
-    repl("Title", fmt.Sprint(data["Title"]))
-    repl("DefaultHue", fmt.Sprint(data["DefaultHue"]))
-    repl("Seed", fmt.Sprint(data["Seed"]))
-    // JSON-quote header strings for safe JS injection
-    repl("HEADER_JSON", jsonQuote(fmt.Sprint(data["Header"])))
-    repl("SUBHEADER_JSON", jsonQuote(fmt.Sprint(data["Subheader"])))
-    repl("CfgCount", fmt.Sprint(data["CfgCount"]))
-    repl("CfgVision", fmt.Sprint(data["CfgVision"]))
-    repl("CfgSep", fmt.Sprint(data["CfgSep"]))
-    repl("CfgMaxSpeed", fmt.Sprint(data["CfgMaxSpeed"]))
-    repl("CfgMinSpeed", fmt.Sprint(data["CfgMinSpeed"]))
-    repl("CfgMaxForce", fmt.Sprint(data["CfgMaxForce"]))
-    repl("CfgAlignW", fmt.Sprint(data["CfgAlignW"]))
-    repl("CfgCohW", fmt.Sprint(data["CfgCohW"]))
-    repl("CfgSepW", fmt.Sprint(data["CfgSepW"]))
-    repl("CfgLineW", fmt.Sprint(data["CfgLineW"]))
-    // Extended fields
-    repl("CfgTrailAlpha", fmt.Sprint(data["CfgTrailAlpha"]))
-    repl("CfgBgHueShift1", fmt.Sprint(data["CfgBgHueShift1"]))
-    repl("CfgBgHueShift2", fmt.Sprint(data["CfgBgHueShift2"]))
-    repl("CfgBgHueShift3", fmt.Sprint(data["CfgBgHueShift3"]))
-    repl("CfgQtCap", fmt.Sprint(data["CfgQtCap"]))
-    repl("CfgFlowAmp", fmt.Sprint(data["CfgFlowAmp"]))
-    repl("CfgFlowScale", fmt.Sprint(data["CfgFlowScale"]))
-    repl("CfgFlowSpeed", fmt.Sprint(data["CfgFlowSpeed"]))
-    repl("CfgFlowVizStep", fmt.Sprint(data["CfgFlowVizStep"]))
-    repl("CfgFlowMode", fmt.Sprint(data["CfgFlowMode"]))
-    repl("CfgFlowAmpVar", fmt.Sprint(data["CfgFlowAmpVar"]))
-    repl("CfgFlowAniso", fmt.Sprint(data["CfgFlowAniso"]))
-    repl("CfgFlowOctaves", fmt.Sprint(data["CfgFlowOctaves"]))
-    repl("CfgFlowHueScale", fmt.Sprint(data["CfgFlowHueScale"]))
-    repl("CfgFlowColorMode", fmt.Sprint(data["CfgFlowColorMode"]))
-    repl("CfgClickMode", fmt.Sprint(data["CfgClickMode"]))
-    repl("CfgDragMode", fmt.Sprint(data["CfgDragMode"]))
-    repl("CfgVisionViz", fmt.Sprint(data["CfgVisionViz"]))
-    repl("CfgShape", fmt.Sprint(data["CfgShape"]))
-    repl("CfgBlend", fmt.Sprint(data["CfgBlend"]))
-    repl("CfgSpawn", fmt.Sprint(data["CfgSpawn"]))
-    // booleans
-    if data["CfgWrap"].(bool) { repl("CfgWrap", "true") } else { repl("CfgWrap", "false") }
-    if data["CfgBgGradient"].(bool) { repl("CfgBgGradient", "true") } else { repl("CfgBgGradient", "false") }
-    if data["CfgQt"].(bool) { repl("CfgQt", "true") } else { repl("CfgQt", "false") }
-    if data["CfgFlow"].(bool) { repl("CfgFlow", "true") } else { repl("CfgFlow", "false") }
-    if data["CfgFlowViz"].(bool) { repl("CfgFlowViz", "true") } else { repl("CfgFlowViz", "false") }
-    if data["CfgFlowColor"].(bool) { repl("CfgFlowColor", "true") } else { repl("CfgFlowColor", "false") }
-    if data["CfgFlowGlow"].(bool) { repl("CfgFlowGlow", "true") } else { repl("CfgFlowGlow", "false") }
-    if data["CfgShowHeader"].(bool) { repl("CfgShowHeader", "true") } else { repl("CfgShowHeader", "false") }
-    if data["CfgShowSubheader"].(bool) { repl("CfgShowSubheader", "true") } else { repl("CfgShowSubheader", "false") }
-    if data["CfgShowHud"].(bool) { repl("CfgShowHud", "true") } else { repl("CfgShowHud", "false") }
-    return out, nil
+// executeTemplate parses and renders tpl as a text/template against data.
+// text/template (rather than html/template) is deliberate: the page embeds
+// raw inline <script>/<style> blocks, and html/template's contextual
+// auto-escaping would mangle them. Values that do need to be safe for JS
+// injection (header/subheader) are pre-quoted into HeaderJSON/SubheaderJSON
+// before data reaches here.
+func executeTemplate(tpl string, data templateData) (string, error) {
+    t, err := template.New("boids-page").Parse(tpl)
+    if err != nil {
+        return "", err
+    }
+    var out strings.Builder
+    if err := t.Execute(&out, data); err != nil {
+        return "", err
+    }
+    return out.String(), nil
 }
 
 func jsonQuote(s string) string {
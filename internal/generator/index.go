@@ -0,0 +1,174 @@
+package generator
+
+import (
+    "encoding/json"
+    "fmt"
+    "html"
+    "os"
+    "path/filepath"
+    "regexp"
+    "sort"
+    "strings"
+)
+
+// IndexOptions controls how RenderIndex / BuildIndex present the gallery.
+type IndexOptions struct {
+    Title string // page title; defaults to "Boid Gallery"
+}
+
+// indexEntry pairs a generated file with the metadata recovered from its
+// embedded boid-meta script tag.
+type indexEntry struct {
+    File string
+    Meta BoidMeta
+}
+
+var metaScriptRE = regexp.MustCompile(`(?s)<script id="boid-meta"[^>]*>(.*?)</script>`)
+
+// RenderIndex scans folder for generated boids-*-impl.html files, recovers
+// each one's embedded BoidMeta, and returns a self-contained index.html with
+// a responsive grid of iframe thumbnails that can be filtered by
+// shape/flow-mode/spawn and sorted by seed.
+func RenderIndex(folder string, opts IndexOptions) (string, error) {
+    matches, err := filepath.Glob(filepath.Join(folder, "boids-*-impl.html"))
+    if err != nil {
+        return "", err
+    }
+    sort.Strings(matches)
+
+    entries := make([]indexEntry, 0, len(matches))
+    for _, m := range matches {
+        b, err := os.ReadFile(m)
+        if err != nil {
+            return "", err
+        }
+        meta := extractMeta(b)
+        entries = append(entries, indexEntry{File: filepath.Base(m), Meta: meta})
+    }
+
+    title := opts.Title
+    if title == "" {
+        title = "Boid Gallery"
+    }
+    return renderIndexHTML(title, entries), nil
+}
+
+// BuildIndex renders the gallery for folder and writes it to
+// folder/index.html.
+func BuildIndex(folder string, opts IndexOptions) error {
+    out, err := RenderIndex(folder, opts)
+    if err != nil {
+        return err
+    }
+    return os.WriteFile(filepath.Join(folder, "index.html"), []byte(out), 0o644)
+}
+
+// extractMeta pulls the BoidMeta out of a generated file's boid-meta script
+// tag. A file that predates the meta tag (or fails to parse) yields a zero
+// value rather than an error, so one bad file doesn't sink the whole index.
+func extractMeta(html []byte) BoidMeta {
+    var meta BoidMeta
+    match := metaScriptRE.FindSubmatch(html)
+    if match == nil {
+        return meta
+    }
+    _ = json.Unmarshal(match[1], &meta)
+    return meta
+}
+
+func renderIndexHTML(title string, entries []indexEntry) string {
+    var cards strings.Builder
+    for _, e := range entries {
+        metaJSON, _ := json.Marshal(e.Meta)
+        fmt.Fprintf(&cards, `<figure class="card" data-meta='%s'>
+  <iframe src="%s" loading="lazy"></iframe>
+  <figcaption>%s &middot; seed=%d &middot; shape=%s &middot; flow=%s</figcaption>
+</figure>
+`, html.EscapeString(string(metaJSON)), html.EscapeString(e.File), html.EscapeString(e.File),
+            e.Meta.Seed, html.EscapeString(e.Meta.Shape), html.EscapeString(e.Meta.FlowMode))
+    }
+
+    return fmt.Sprintf(`<!doctype html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%s</title>
+<style>
+  body { background: #0b0b10; color: #eee; font-family: system-ui, sans-serif; margin: 0; padding: 1.5rem; }
+  h1 { font-size: 1.25rem; margin: 0 0 1rem; }
+  .controls { display: flex; gap: 0.75rem; margin-bottom: 1rem; flex-wrap: wrap; }
+  .controls select { background: #1a1a22; color: #eee; border: 1px solid #333; border-radius: 4px; padding: 0.3rem 0.5rem; }
+  .grid { display: grid; grid-template-columns: repeat(auto-fill, minmax(260px, 1fr)); gap: 1rem; }
+  .card { margin: 0; background: #14141c; border: 1px solid #222; border-radius: 6px; overflow: hidden; }
+  .card iframe { width: 100%%; height: 180px; border: 0; display: block; background: #000; }
+  .card figcaption { font-size: 0.75rem; padding: 0.4rem 0.5rem; color: #aaa; white-space: nowrap; overflow: hidden; text-overflow: ellipsis; }
+  .card.hidden { display: none; }
+</style>
+</head>
+<body>
+<h1>%s</h1>
+<div class="controls">
+  <select id="filter-shape"><option value="">All shapes</option></select>
+  <select id="filter-flow"><option value="">All flow modes</option></select>
+  <select id="filter-spawn"><option value="">All spawn modes</option></select>
+  <select id="sort-by">
+    <option value="">File order</option>
+    <option value="seed">Sort by seed</option>
+  </select>
+</div>
+<div class="grid" id="grid">
+%s</div>
+<script>
+(function () {
+  var grid = document.getElementById('grid');
+  var cards = Array.prototype.slice.call(grid.querySelectorAll('.card'));
+  cards.forEach(function (c) { c.meta = JSON.parse(c.getAttribute('data-meta')); });
+
+  function populate(select, key) {
+    var seen = {};
+    cards.forEach(function (c) {
+      var v = c.meta[key];
+      if (v && !seen[v]) {
+        seen[v] = true;
+        var opt = document.createElement('option');
+        opt.value = v;
+        opt.textContent = v;
+        select.appendChild(opt);
+      }
+    });
+  }
+  populate(document.getElementById('filter-shape'), 'shape');
+  populate(document.getElementById('filter-flow'), 'flowMode');
+  populate(document.getElementById('filter-spawn'), 'spawn');
+
+  function apply() {
+    var shape = document.getElementById('filter-shape').value;
+    var flow = document.getElementById('filter-flow').value;
+    var spawn = document.getElementById('filter-spawn').value;
+    var sortBy = document.getElementById('sort-by').value;
+
+    cards.forEach(function (c) {
+      var visible = (!shape || c.meta.shape === shape) &&
+        (!flow || c.meta.flowMode === flow) &&
+        (!spawn || c.meta.spawn === spawn);
+      c.classList.toggle('hidden', !visible);
+    });
+
+    var ordered = cards;
+    if (sortBy === 'seed') {
+      ordered = cards.slice().sort(function (a, b) {
+        return (a.meta.seed || 0) - (b.meta.seed || 0);
+      });
+    }
+    ordered.forEach(function (c) { grid.appendChild(c); });
+  }
+
+  ['filter-shape', 'filter-flow', 'filter-spawn', 'sort-by'].forEach(function (id) {
+    document.getElementById(id).addEventListener('change', apply);
+  });
+})();
+</script>
+</body>
+</html>
+`, html.EscapeString(title), html.EscapeString(title), cards.String())
+}
@@ -0,0 +1,41 @@
+package generator
+
+import "testing"
+
+func TestMetaScriptTagRoundTrip(t *testing.T) {
+    want := BoidMeta{
+        Seed:      12345,
+        Title:     "Boids (Go) · N=220 · hue=90",
+        Count:     220,
+        Shape:     "ring",
+        Blend:     "screen",
+        Spawn:     "center",
+        Flow:      true,
+        FlowMode:  "curl",
+        ClickMode: "gravity",
+        DragMode:  "pull",
+    }
+
+    page := "<html><body><p>hi</p></body></html>"
+    page = injectMeta(page, want)
+
+    got := extractMeta([]byte(page))
+    if got != want {
+        t.Errorf("extractMeta round trip = %+v, want %+v", got, want)
+    }
+}
+
+func TestExtractMeta_MissingTagReturnsZeroValue(t *testing.T) {
+    got := extractMeta([]byte("<html><body>no meta here</body></html>"))
+    if got != (BoidMeta{}) {
+        t.Errorf("extractMeta with no tag = %+v, want zero value", got)
+    }
+}
+
+func TestExtractMeta_MalformedJSONReturnsZeroValue(t *testing.T) {
+    html := `<html><body><script id="boid-meta" type="application/json">not json</script></body></html>`
+    got := extractMeta([]byte(html))
+    if got != (BoidMeta{}) {
+        t.Errorf("extractMeta with malformed JSON = %+v, want zero value", got)
+    }
+}
@@ -0,0 +1,205 @@
+package generator
+
+import (
+    "fmt"
+    "net/url"
+    "strconv"
+    "strings"
+)
+
+// ApplyQueryOverrides applies URL query parameter overrides onto a base
+// Config (typically the seeded defaults from SeedConfig) and returns the
+// result. Parameters map onto Config fields by camelCase name (e.g.
+// ?count=300&flowMode=curl&clickMode=gravity). Unrecognized parameters are
+// ignored; a malformed value for a recognized parameter returns an error
+// naming it.
+func ApplyQueryOverrides(base Config, q url.Values) (Config, error) {
+    cfg := base
+
+    if err := parseIntOverride(q, "hue", &cfg.Hue); err != nil {
+        return base, err
+    }
+    if err := parseIntOverride(q, "count", &cfg.Count); err != nil {
+        return base, err
+    }
+    if err := parseFloatOverride(q, "vision", &cfg.Vision); err != nil {
+        return base, err
+    }
+    if err := parseFloatOverride(q, "sep", &cfg.Sep); err != nil {
+        return base, err
+    }
+    if err := parseFloatOverride(q, "maxSpeed", &cfg.MaxSpeed); err != nil {
+        return base, err
+    }
+    if err := parseFloatOverride(q, "minSpeed", &cfg.MinSpeed); err != nil {
+        return base, err
+    }
+    if err := parseFloatOverride(q, "maxForce", &cfg.MaxForce); err != nil {
+        return base, err
+    }
+    if err := parseFloatOverride(q, "alignW", &cfg.AlignW); err != nil {
+        return base, err
+    }
+    if err := parseFloatOverride(q, "cohesionW", &cfg.CohesionW); err != nil {
+        return base, err
+    }
+    if err := parseFloatOverride(q, "separationW", &cfg.SeparationW); err != nil {
+        return base, err
+    }
+    if err := parseFloatOverride(q, "lineWidth", &cfg.LineWidth); err != nil {
+        return base, err
+    }
+    if err := parseBoolOverride(q, "wrap", &cfg.Wrap); err != nil {
+        return base, err
+    }
+    if err := parseFloatOverride(q, "trailAlpha", &cfg.TrailAlpha); err != nil {
+        return base, err
+    }
+    if err := parseBoolOverride(q, "bgGradient", &cfg.BgGradient); err != nil {
+        return base, err
+    }
+    if err := parseIntOverride(q, "bgHueShift1", &cfg.BgHueShift1); err != nil {
+        return base, err
+    }
+    if err := parseIntOverride(q, "bgHueShift2", &cfg.BgHueShift2); err != nil {
+        return base, err
+    }
+    if err := parseIntOverride(q, "bgHueShift3", &cfg.BgHueShift3); err != nil {
+        return base, err
+    }
+    if err := parseBoolOverride(q, "qt", &cfg.Qt); err != nil {
+        return base, err
+    }
+    if err := parseIntOverride(q, "qtCap", &cfg.QtCap); err != nil {
+        return base, err
+    }
+    if err := parseBoolOverride(q, "flow", &cfg.Flow); err != nil {
+        return base, err
+    }
+    if err := parseFloatOverride(q, "flowAmp", &cfg.FlowAmp); err != nil {
+        return base, err
+    }
+    if err := parseFloatOverride(q, "flowScale", &cfg.FlowScale); err != nil {
+        return base, err
+    }
+    if err := parseFloatOverride(q, "flowSpeed", &cfg.FlowSpeed); err != nil {
+        return base, err
+    }
+    if err := parseBoolOverride(q, "flowViz", &cfg.FlowViz); err != nil {
+        return base, err
+    }
+    if err := parseIntOverride(q, "flowVizStep", &cfg.FlowVizStep); err != nil {
+        return base, err
+    }
+    if err := parseEnumOverride(q, "flowMode", flowModeValues, &cfg.FlowMode); err != nil {
+        return base, err
+    }
+    if err := parseFloatOverride(q, "flowAmpVar", &cfg.FlowAmpVar); err != nil {
+        return base, err
+    }
+    if err := parseFloatOverride(q, "flowAniso", &cfg.FlowAniso); err != nil {
+        return base, err
+    }
+    if err := parseIntOverride(q, "flowOctaves", &cfg.FlowOctaves); err != nil {
+        return base, err
+    }
+    if err := parseBoolOverride(q, "flowColor", &cfg.FlowColor); err != nil {
+        return base, err
+    }
+    if err := parseFloatOverride(q, "flowHueScale", &cfg.FlowHueScale); err != nil {
+        return base, err
+    }
+    if err := parseEnumOverride(q, "flowColorMode", flowColorModeValues, &cfg.FlowColorMode); err != nil {
+        return base, err
+    }
+    if err := parseBoolOverride(q, "flowGlow", &cfg.FlowGlow); err != nil {
+        return base, err
+    }
+    if err := parseBoolOverride(q, "showHeader", &cfg.ShowHeader); err != nil {
+        return base, err
+    }
+    if err := parseBoolOverride(q, "showSubheader", &cfg.ShowSubheader); err != nil {
+        return base, err
+    }
+    if err := parseBoolOverride(q, "showHud", &cfg.ShowHud); err != nil {
+        return base, err
+    }
+    if err := parseEnumOverride(q, "clickMode", clickModeValues, &cfg.ClickMode); err != nil {
+        return base, err
+    }
+    if err := parseEnumOverride(q, "dragMode", dragModeValues, &cfg.DragMode); err != nil {
+        return base, err
+    }
+    if err := parseEnumOverride(q, "visionViz", visionVizValues, &cfg.VisionViz); err != nil {
+        return base, err
+    }
+    if err := parseEnumOverride(q, "shape", shapeValues, &cfg.Shape); err != nil {
+        return base, err
+    }
+    if err := parseEnumOverride(q, "blend", blendValues, &cfg.Blend); err != nil {
+        return base, err
+    }
+    if err := parseEnumOverride(q, "spawn", spawnValues, &cfg.Spawn); err != nil {
+        return base, err
+    }
+
+    return cfg, nil
+}
+
+func parseIntOverride(q url.Values, key string, dst *int) error {
+    v := q.Get(key)
+    if v == "" {
+        return nil
+    }
+    n, err := strconv.Atoi(v)
+    if err != nil {
+        return fmt.Errorf("%s: %w", key, err)
+    }
+    *dst = n
+    return nil
+}
+
+func parseFloatOverride(q url.Values, key string, dst *float64) error {
+    v := q.Get(key)
+    if v == "" {
+        return nil
+    }
+    n, err := strconv.ParseFloat(v, 64)
+    if err != nil {
+        return fmt.Errorf("%s: %w", key, err)
+    }
+    *dst = n
+    return nil
+}
+
+func parseBoolOverride(q url.Values, key string, dst *bool) error {
+    v := q.Get(key)
+    if v == "" {
+        return nil
+    }
+    b, err := strconv.ParseBool(v)
+    if err != nil {
+        return fmt.Errorf("%s: %w", key, err)
+    }
+    *dst = b
+    return nil
+}
+
+// parseEnumOverride sets *dst to q[key] if present, provided it appears in
+// allowed. These fields (Shape, FlowMode, ClickMode, ...) flow unescaped
+// into the page template (see executeTemplate's doc comment), so unlike the
+// numeric/bool overrides above, an unrecognized value is rejected outright
+// rather than silently applied.
+func parseEnumOverride(q url.Values, key string, allowed []string, dst *string) error {
+    v := q.Get(key)
+    if v == "" {
+        return nil
+    }
+    for _, a := range allowed {
+        if v == a {
+            *dst = v
+            return nil
+        }
+    }
+    return fmt.Errorf("%s: invalid value %q (want one of %s)", key, v, strings.Join(allowed, ", "))
+}
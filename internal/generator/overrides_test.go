@@ -0,0 +1,101 @@
+package generator
+
+import (
+    "net/url"
+    "testing"
+)
+
+func TestApplyQueryOverrides_ValidValues(t *testing.T) {
+    base := Config{Count: 10, Vision: 1.5, Wrap: false, Shape: "dot"}
+    q := url.Values{
+        "count": {"42"},
+        "vision": {"2.5"},
+        "wrap":  {"true"},
+        "shape": {"ring"},
+    }
+
+    got, err := ApplyQueryOverrides(base, q)
+    if err != nil {
+        t.Fatalf("unexpected error: %v", err)
+    }
+    if got.Count != 42 {
+        t.Errorf("Count = %d, want 42", got.Count)
+    }
+    if got.Vision != 2.5 {
+        t.Errorf("Vision = %v, want 2.5", got.Vision)
+    }
+    if !got.Wrap {
+        t.Errorf("Wrap = false, want true")
+    }
+    if got.Shape != "ring" {
+        t.Errorf("Shape = %q, want ring", got.Shape)
+    }
+}
+
+func TestApplyQueryOverrides_UnknownKeysIgnored(t *testing.T) {
+    base := Config{Count: 10, Shape: "dot"}
+    q := url.Values{"bogus": {"whatever"}}
+
+    got, err := ApplyQueryOverrides(base, q)
+    if err != nil {
+        t.Fatalf("unexpected error: %v", err)
+    }
+    if got != base {
+        t.Errorf("ApplyQueryOverrides changed config for unknown key: got %+v, want %+v", got, base)
+    }
+}
+
+func TestApplyQueryOverrides_InvalidInt(t *testing.T) {
+    base := Config{Count: 10}
+    _, err := ApplyQueryOverrides(base, url.Values{"count": {"not-a-number"}})
+    if err == nil {
+        t.Fatal("expected error for invalid count, got nil")
+    }
+}
+
+func TestApplyQueryOverrides_InvalidFloat(t *testing.T) {
+    base := Config{Vision: 1.0}
+    _, err := ApplyQueryOverrides(base, url.Values{"vision": {"nope"}})
+    if err == nil {
+        t.Fatal("expected error for invalid vision, got nil")
+    }
+}
+
+func TestApplyQueryOverrides_InvalidBool(t *testing.T) {
+    base := Config{Wrap: false}
+    _, err := ApplyQueryOverrides(base, url.Values{"wrap": {"maybe"}})
+    if err == nil {
+        t.Fatal("expected error for invalid wrap, got nil")
+    }
+}
+
+func TestApplyQueryOverrides_UnknownEnumValueRejected(t *testing.T) {
+    base := Config{Shape: "dot"}
+    _, err := ApplyQueryOverrides(base, url.Values{"shape": {`x"};alert(1)//`}})
+    if err == nil {
+        t.Fatal("expected error for unrecognized shape value, got nil")
+    }
+}
+
+func TestApplyQueryOverrides_EnumValuesMatchDefaults(t *testing.T) {
+    cases := []struct {
+        key     string
+        allowed []string
+    }{
+        {"flowMode", flowModeValues},
+        {"flowColorMode", flowColorModeValues},
+        {"clickMode", clickModeValues},
+        {"dragMode", dragModeValues},
+        {"visionViz", visionVizValues},
+        {"shape", shapeValues},
+        {"blend", blendValues},
+        {"spawn", spawnValues},
+    }
+    for _, c := range cases {
+        for _, v := range c.allowed {
+            if _, err := ApplyQueryOverrides(Config{}, url.Values{c.key: {v}}); err != nil {
+                t.Errorf("%s=%q: unexpected error: %v", c.key, v, err)
+            }
+        }
+    }
+}